@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tockn/singo/handler"
+	"github.com/tockn/singo/repository"
+	boltrepo "github.com/tockn/singo/repository/bolt"
+	"github.com/tockn/singo/repository/mem"
+	"github.com/tockn/singo/repository/redis"
+)
+
+const envStore = "SINGO_STORE"
+
+// newRoomRepository picks the repository.Room backend from the SINGO_STORE
+// env var so operators can run multiple signaling nodes against a shared
+// store, or survive a restart, instead of the single-process in-memory
+// default.
+func newRoomRepository() (repository.Room, error) {
+	switch store := os.Getenv(envStore); store {
+	case "redis":
+		client := goredis.NewClient(&goredis.Options{
+			Addr: os.Getenv("SINGO_REDIS_ADDR"),
+		})
+		return redis.NewRoomRepository(client), nil
+	case "bolt":
+		path := os.Getenv("SINGO_BOLT_PATH")
+		if path == "" {
+			path = "singo.db"
+		}
+		db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+		if err != nil {
+			return nil, err
+		}
+		return boltrepo.NewRoomRepository(db)
+	case "mem", "":
+		return mem.NewRoomRepository(), nil
+	default:
+		return nil, fmt.Errorf("singo: unknown %s %q", envStore, store)
+	}
+}
+
+func main() {
+	roomRepo, err := newRoomRepository()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("singo: room store ready (%T)", roomRepo)
+
+	roomHandler := handler.NewRoomHandler(roomRepo)
+	http.HandleFunc("/rooms", roomHandler.List)
+
+	addr := ":8080"
+	log.Printf("singo: admin API listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, nil))
+}
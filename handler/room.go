@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/tockn/singo/repository"
+)
+
+type RoomHandler struct {
+	roomRepo repository.Room
+}
+
+func NewRoomHandler(roomRepo repository.Room) *RoomHandler {
+	return &RoomHandler{roomRepo: roomRepo}
+}
+
+type roomSummary struct {
+	ID          string `json:"id"`
+	ClientCount int    `json:"clientCount"`
+}
+
+type listRoomsResponse struct {
+	Rooms []roomSummary `json:"rooms"`
+	Total int           `json:"total"`
+}
+
+// List handles GET /rooms?offset=&limit=, returning a page of active rooms
+// and their peer counts for monitoring.
+func (h *RoomHandler) List(w http.ResponseWriter, r *http.Request) {
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := queryInt(r, "limit", 50)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rooms, total, err := h.roomRepo.List(offset, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := listRoomsResponse{
+		Rooms: make([]roomSummary, 0, len(rooms)),
+		Total: total,
+	}
+	for _, room := range rooms {
+		resp.Rooms = append(resp.Rooms, roomSummary{
+			ID:          room.ID,
+			ClientCount: len(room.Clients),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
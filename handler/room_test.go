@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tockn/singo/model"
+	"github.com/tockn/singo/repository/mem"
+)
+
+func TestRoomHandler_List(t *testing.T) {
+	roomRepo := mem.NewRoomRepository()
+	if _, err := roomRepo.Create(&model.Room{
+		ID:      "room-1",
+		Clients: []*model.Client{{ID: "client-1"}, {ID: "client-2"}},
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	h := NewRoomHandler(roomRepo)
+	req := httptest.NewRequest(http.MethodGet, "/rooms?offset=0&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	h.List(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+}
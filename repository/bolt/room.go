@@ -0,0 +1,186 @@
+package bolt
+
+import (
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tockn/singo/model"
+	"github.com/tockn/singo/repository"
+)
+
+var (
+	bucketRooms   = []byte("rooms")
+	bucketClients = []byte("clients")
+)
+
+// NewRoomRepository opens the rooms/clients buckets used to persist room
+// state across restarts so the server can reload live rooms on startup
+// instead of forcing every peer to re-signal.
+func NewRoomRepository(db *bolt.DB) (repository.Room, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(bucketRooms); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(bucketClients)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &roomRepository{db: db}, nil
+}
+
+type roomRepository struct {
+	db *bolt.DB
+}
+
+func (re *roomRepository) Get(roomID string) (*model.Room, error) {
+	var r *model.Room
+	err := re.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRooms).Get([]byte(roomID))
+		if b == nil {
+			return repository.ErrNotFound
+		}
+		r = &model.Room{}
+		return json.Unmarshal(b, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (re *roomRepository) Create(r *model.Room) (*model.Room, error) {
+	return re.save(r)
+}
+
+// Update replaces the room's state and reindexes its clients in a single
+// transaction, so a client dropped from r.Clients loses its reverse index
+// entry and a concurrent Delete can't resurrect a room this call raced with.
+func (re *roomRepository) Update(r *model.Room) (*model.Room, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = re.db.Update(func(tx *bolt.Tx) error {
+		rooms := tx.Bucket(bucketRooms)
+		old := rooms.Get([]byte(r.ID))
+		if old == nil {
+			return repository.ErrNotFound
+		}
+		oldRoom := &model.Room{}
+		if err := json.Unmarshal(old, oldRoom); err != nil {
+			return err
+		}
+
+		clients := tx.Bucket(bucketClients)
+		for _, c := range oldRoom.Clients {
+			if err := clients.Delete([]byte(c.ID)); err != nil {
+				return err
+			}
+		}
+		if err := rooms.Put([]byte(r.ID), b); err != nil {
+			return err
+		}
+		for _, c := range r.Clients {
+			if err := clients.Put([]byte(c.ID), []byte(r.ID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (re *roomRepository) Delete(roomID string) error {
+	return re.db.Update(func(tx *bolt.Tx) error {
+		rooms := tx.Bucket(bucketRooms)
+		b := rooms.Get([]byte(roomID))
+		if b == nil {
+			return repository.ErrNotFound
+		}
+
+		r := &model.Room{}
+		if err := json.Unmarshal(b, r); err != nil {
+			return err
+		}
+
+		clients := tx.Bucket(bucketClients)
+		for _, c := range r.Clients {
+			if err := clients.Delete([]byte(c.ID)); err != nil {
+				return err
+			}
+		}
+		return rooms.Delete([]byte(roomID))
+	})
+}
+
+func (re *roomRepository) GetByClientID(clientID string) (*model.Room, error) {
+	var roomID string
+	err := re.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketClients).Get([]byte(clientID))
+		if b == nil {
+			return repository.ErrNotFound
+		}
+		roomID = string(b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return re.Get(roomID)
+}
+
+func (re *roomRepository) List(offset, limit int) ([]*model.Room, int, error) {
+	var rooms []*model.Room
+	err := re.db.View(func(tx *bolt.Tx) error {
+		// Bucket keys are room IDs, and bbolt's ForEach walks them in
+		// byte-sorted order, so this is already deterministic pagination.
+		return tx.Bucket(bucketRooms).ForEach(func(_, v []byte) error {
+			r := &model.Room{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return err
+			}
+			rooms = append(rooms, r)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(rooms)
+	offset, end := repository.Paginate(total, offset, limit)
+	return rooms[offset:end], total, nil
+}
+
+// save persists the room and its clients' reverse index entries in a single
+// bbolt transaction so Create/Update remain atomic.
+func (re *roomRepository) save(r *model.Room) (*model.Room, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = re.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(bucketRooms).Put([]byte(r.ID), b); err != nil {
+			return err
+		}
+		clients := tx.Bucket(bucketClients)
+		for _, c := range r.Clients {
+			if err := clients.Put([]byte(c.ID), []byte(r.ID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
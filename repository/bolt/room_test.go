@@ -0,0 +1,31 @@
+package bolt
+
+import (
+	"path/filepath"
+	"testing"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/tockn/singo/repository"
+	"github.com/tockn/singo/repository/roomtest"
+)
+
+func newTestRepository(t *testing.T) repository.Room {
+	t.Helper()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "singo.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("bbolt.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	re, err := NewRoomRepository(db)
+	if err != nil {
+		t.Fatalf("NewRoomRepository: %v", err)
+	}
+	return re
+}
+
+func TestRoomRepository(t *testing.T) {
+	roomtest.Run(t, func() repository.Room { return newTestRepository(t) })
+}
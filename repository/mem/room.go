@@ -1,21 +1,36 @@
 package mem
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/tockn/singo/model"
 	"github.com/tockn/singo/repository"
 )
 
 func NewRoomRepository() repository.Room {
 	return &roomRepository{
-		rooms: make(map[string]*model.Room, 0),
+		rooms:       make(map[string]*model.Room, 0),
+		clientIndex: make(map[string]string, 0),
+		roomClients: make(map[string][]string, 0),
 	}
 }
 
 type roomRepository struct {
+	mu    sync.RWMutex
 	rooms map[string]*model.Room
+	// clientIndex maps a client ID to the room it currently belongs to, so
+	// GetByClientID doesn't have to scan every room and every client.
+	clientIndex map[string]string
+	// roomClients records the client IDs each room was last indexed with, so
+	// they can be deindexed even if the caller mutated the stored room.
+	roomClients map[string][]string
 }
 
 func (re *roomRepository) Get(roomID string) (*model.Room, error) {
+	re.mu.RLock()
+	defer re.mu.RUnlock()
+
 	r, ok := re.rooms[roomID]
 	if !ok {
 		return nil, repository.ErrNotFound
@@ -24,26 +39,86 @@ func (re *roomRepository) Get(roomID string) (*model.Room, error) {
 }
 
 func (re *roomRepository) Update(r *model.Room) (*model.Room, error) {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+
 	if _, ok := re.rooms[r.ID]; !ok {
 		return nil, repository.ErrNotFound
 	}
+	re.deindexClients(r.ID)
 	re.rooms[r.ID] = r
+	re.indexClients(r)
 	return r, nil
 }
 
 func (re *roomRepository) Create(r *model.Room) (*model.Room, error) {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+
 	re.rooms[r.ID] = r
+	re.indexClients(r)
 	return r, nil
 }
 
+func (re *roomRepository) Delete(roomID string) error {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+
+	if _, ok := re.rooms[roomID]; !ok {
+		return repository.ErrNotFound
+	}
+	re.deindexClients(roomID)
+	delete(re.rooms, roomID)
+	return nil
+}
+
 func (re *roomRepository) GetByClientID(clientID string) (*model.Room, error) {
-	for _, r := range re.rooms {
-		for _, c := range r.Clients {
-			if c.ID != clientID {
-				continue
-			}
-			return r, nil
-		}
-	}
-	return nil, repository.ErrNotFound
+	re.mu.RLock()
+	defer re.mu.RUnlock()
+
+	roomID, ok := re.clientIndex[clientID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	r, ok := re.rooms[roomID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return r, nil
+}
+
+func (re *roomRepository) List(offset, limit int) ([]*model.Room, int, error) {
+	re.mu.RLock()
+	defer re.mu.RUnlock()
+
+	ids := make([]string, 0, len(re.rooms))
+	for id := range re.rooms {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	total := len(ids)
+	offset, end := repository.Paginate(total, offset, limit)
+
+	rooms := make([]*model.Room, 0, end-offset)
+	for _, id := range ids[offset:end] {
+		rooms = append(rooms, re.rooms[id])
+	}
+	return rooms, total, nil
+}
+
+func (re *roomRepository) indexClients(r *model.Room) {
+	ids := make([]string, 0, len(r.Clients))
+	for _, c := range r.Clients {
+		re.clientIndex[c.ID] = r.ID
+		ids = append(ids, c.ID)
+	}
+	re.roomClients[r.ID] = ids
+}
+
+func (re *roomRepository) deindexClients(roomID string) {
+	for _, id := range re.roomClients[roomID] {
+		delete(re.clientIndex, id)
+	}
+	delete(re.roomClients, roomID)
 }
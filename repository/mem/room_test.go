@@ -0,0 +1,59 @@
+package mem
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/tockn/singo/model"
+	"github.com/tockn/singo/repository"
+	"github.com/tockn/singo/repository/roomtest"
+)
+
+func TestRoomRepository(t *testing.T) {
+	roomtest.Run(t, func() repository.Room { return NewRoomRepository() })
+}
+
+// TestRoomRepository_ConcurrentAccess exercises Create/Update/Get/GetByClientID
+// from many goroutines at once, mirroring how one websocket connection per
+// goroutine hammers the repository in production. Run with `go test -race`
+// to catch data races on the shared rooms map.
+func TestRoomRepository_ConcurrentAccess(t *testing.T) {
+	const n = 100
+	re := NewRoomRepository()
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			roomID := fmt.Sprintf("room-%d", i)
+			clientID := fmt.Sprintf("client-%d", i)
+			r := &model.Room{
+				ID: roomID,
+				Clients: []*model.Client{
+					{ID: clientID},
+				},
+			}
+
+			if _, err := re.Create(r); err != nil {
+				t.Errorf("Create(%s): %v", roomID, err)
+				return
+			}
+			if _, err := re.Update(r); err != nil {
+				t.Errorf("Update(%s): %v", roomID, err)
+				return
+			}
+			if _, err := re.Get(roomID); err != nil {
+				t.Errorf("Get(%s): %v", roomID, err)
+				return
+			}
+			if _, err := re.GetByClientID(clientID); err != nil {
+				t.Errorf("GetByClientID(%s): %v", clientID, err)
+				return
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,20 @@
+package repository
+
+// Paginate clamps an offset/limit pair against total into a [start, end)
+// range that's always safe to slice a total-length collection with. It's
+// shared by every Room backend's List so the offset+limit arithmetic isn't
+// reimplemented (and re-broken) independently in each one; in particular it
+// never computes offset+limit when that could overflow int, which a naive
+// `end := offset + limit` does for a large attacker-supplied limit.
+func Paginate(total, offset, limit int) (start, end int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	if limit <= 0 || limit > total-offset {
+		return offset, total
+	}
+	return offset, offset + limit
+}
@@ -0,0 +1,157 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/tockn/singo/model"
+	"github.com/tockn/singo/repository"
+)
+
+const (
+	roomKeyPrefix   = "room:"
+	clientKeyPrefix = "client:"
+	// roomDataField is the hash field room:{id} stores its JSON blob under.
+	roomDataField = "data"
+)
+
+func NewRoomRepository(c *redis.Client) repository.Room {
+	return &roomRepository{
+		client: c,
+		ctx:    context.Background(),
+	}
+}
+
+type roomRepository struct {
+	client *redis.Client
+	ctx    context.Context
+}
+
+func roomKey(roomID string) string {
+	return roomKeyPrefix + roomID
+}
+
+func clientKey(clientID string) string {
+	return clientKeyPrefix + clientID
+}
+
+func (re *roomRepository) Get(roomID string) (*model.Room, error) {
+	b, err := re.client.HGet(re.ctx, roomKey(roomID), roomDataField).Bytes()
+	if err == redis.Nil {
+		return nil, repository.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	r := &model.Room{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (re *roomRepository) Create(r *model.Room) (*model.Room, error) {
+	return re.save(nil, r)
+}
+
+func (re *roomRepository) Update(r *model.Room) (*model.Room, error) {
+	old, err := re.Get(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	return re.save(old, r)
+}
+
+func (re *roomRepository) Delete(roomID string) error {
+	r, err := re.Get(roomID)
+	if err != nil {
+		return err
+	}
+
+	pipe := re.client.TxPipeline()
+	pipe.Del(re.ctx, roomKey(roomID))
+	for _, c := range r.Clients {
+		pipe.Del(re.ctx, clientKey(c.ID))
+	}
+	_, err = pipe.Exec(re.ctx)
+	return err
+}
+
+func (re *roomRepository) GetByClientID(clientID string) (*model.Room, error) {
+	roomID, err := re.client.Get(re.ctx, clientKey(clientID)).Result()
+	if err == redis.Nil {
+		return nil, repository.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return re.Get(roomID)
+}
+
+func (re *roomRepository) List(offset, limit int) ([]*model.Room, int, error) {
+	var keys []string
+	iter := re.client.Scan(re.ctx, 0, roomKeyPrefix+"*", 0).Iterator()
+	for iter.Next(re.ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, 0, err
+	}
+	sort.Strings(keys)
+
+	total := len(keys)
+	offset, end := repository.Paginate(total, offset, limit)
+
+	rooms := make([]*model.Room, 0, end-offset)
+	for _, key := range keys[offset:end] {
+		b, err := re.client.HGet(re.ctx, key, roomDataField).Bytes()
+		if err == redis.Nil {
+			// The room was deleted between the scan and this fetch (normal
+			// under the concurrent joins/leaves this backend exists for) —
+			// drop it from the page instead of failing the whole call.
+			total--
+			continue
+		} else if err != nil {
+			return nil, 0, err
+		}
+		r := &model.Room{}
+		if err := json.Unmarshal(b, r); err != nil {
+			return nil, 0, err
+		}
+		rooms = append(rooms, r)
+	}
+	return rooms, total, nil
+}
+
+// save writes the room hash and refreshes the client->room reverse index in a
+// single pipeline so a crash between the two writes can't be observed. old is
+// the room's previous state, if any, so clients removed from r.Clients lose
+// their reverse index entry instead of going stale.
+func (re *roomRepository) save(old, r *model.Room) (*model.Room, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := re.client.TxPipeline()
+	if old != nil {
+		newClients := make(map[string]struct{}, len(r.Clients))
+		for _, c := range r.Clients {
+			newClients[c.ID] = struct{}{}
+		}
+		for _, c := range old.Clients {
+			if _, ok := newClients[c.ID]; !ok {
+				pipe.Del(re.ctx, clientKey(c.ID))
+			}
+		}
+	}
+	pipe.HSet(re.ctx, roomKey(r.ID), roomDataField, b)
+	for _, c := range r.Clients {
+		pipe.Set(re.ctx, clientKey(c.ID), r.ID, 0)
+	}
+	if _, err := pipe.Exec(re.ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/tockn/singo/repository"
+	"github.com/tockn/singo/repository/roomtest"
+)
+
+func newTestRepository(t *testing.T) repository.Room {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRoomRepository(client)
+}
+
+func TestRoomRepository(t *testing.T) {
+	roomtest.Run(t, func() repository.Room { return newTestRepository(t) })
+}
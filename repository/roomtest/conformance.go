@@ -0,0 +1,120 @@
+// Package roomtest is a conformance suite shared by every repository.Room
+// backend, so Create/Get/Update/Delete/List behavior is asserted once and
+// each backend's _test.go just supplies a constructor instead of
+// copy-pasting the same fixtures.
+package roomtest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tockn/singo/model"
+	"github.com/tockn/singo/repository"
+)
+
+// Run exercises the full repository.Room contract against a fresh
+// implementation returned by factory for each sub-test.
+func Run(t *testing.T, factory func() repository.Room) {
+	t.Run("CreateGetDelete", func(t *testing.T) { testCreateGetDelete(t, factory()) })
+	t.Run("List", func(t *testing.T) { testList(t, factory()) })
+	t.Run("ListPaginationOverflow", func(t *testing.T) { testListPaginationOverflow(t, factory()) })
+	t.Run("UpdateDropsStaleClientIndex", func(t *testing.T) { testUpdateDropsStaleClientIndex(t, factory()) })
+}
+
+func testCreateGetDelete(t *testing.T, re repository.Room) {
+	t.Helper()
+
+	r := &model.Room{
+		ID:      "room-1",
+		Clients: []*model.Client{{ID: "client-1"}},
+	}
+
+	if _, err := re.Create(r); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := re.Get(r.ID); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if _, err := re.GetByClientID("client-1"); err != nil {
+		t.Fatalf("GetByClientID: %v", err)
+	}
+
+	if err := re.Delete(r.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := re.Get(r.ID); err != repository.ErrNotFound {
+		t.Errorf("Get after Delete: got %v, want %v", err, repository.ErrNotFound)
+	}
+	if _, err := re.GetByClientID("client-1"); err != repository.ErrNotFound {
+		t.Errorf("GetByClientID after Delete: got %v, want %v", err, repository.ErrNotFound)
+	}
+}
+
+func testList(t *testing.T, re repository.Room) {
+	t.Helper()
+
+	for _, id := range []string{"room-a", "room-b", "room-c"} {
+		if _, err := re.Create(&model.Room{ID: id}); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+
+	rooms, total, err := re.List(1, 1)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("total = %d, want 3", total)
+	}
+	if len(rooms) != 1 || rooms[0].ID != "room-b" {
+		t.Errorf("List(1, 1) = %+v, want [room-b]", rooms)
+	}
+}
+
+// testListPaginationOverflow pins the bug where a huge limit made
+// offset+limit wrap negative and panic slicing the backing collection.
+func testListPaginationOverflow(t *testing.T, re repository.Room) {
+	t.Helper()
+
+	if _, err := re.Create(&model.Room{ID: "room-1"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	rooms, total, err := re.List(1, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 {
+		t.Errorf("total = %d, want 1", total)
+	}
+	if len(rooms) != 0 {
+		t.Errorf("rooms = %+v, want none (offset is past total)", rooms)
+	}
+}
+
+func testUpdateDropsStaleClientIndex(t *testing.T, re repository.Room) {
+	t.Helper()
+
+	r := &model.Room{
+		ID: "room-1",
+		Clients: []*model.Client{
+			{ID: "client-a"},
+			{ID: "client-b"},
+		},
+	}
+	if _, err := re.Create(r); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	r.Clients = []*model.Client{{ID: "client-b"}}
+	if _, err := re.Update(r); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := re.GetByClientID("client-a"); err != repository.ErrNotFound {
+		t.Errorf("GetByClientID(client-a) after Update: got %v, want %v", err, repository.ErrNotFound)
+	}
+	if _, err := re.GetByClientID("client-b"); err != nil {
+		t.Errorf("GetByClientID(client-b) after Update: %v", err)
+	}
+}